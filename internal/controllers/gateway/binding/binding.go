@@ -0,0 +1,179 @@
+// Package binding implements the Route<->Listener binding engine used by the Gateway
+// controller: given a Gateway's listeners and the set of HTTPRoute/TCPRoute/TLSRoute/
+// UDPRoute objects in scope, it works out which routes bind to which listeners and
+// returns every status update that results from that computation.
+//
+// The engine is a set of pure functions: Bind takes a snapshot of the relevant state and
+// returns a BindResult describing the route and listener status updates that should be
+// applied. It does not talk to the API server itself, which keeps it easy to unit test
+// and lets the caller apply the whole result in a single, atomic batch rather than
+// letting listener AttachedRoutes counts and route conditions drift out of sync with
+// each other across reconciles.
+package binding
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// Route is the subset of an HTTPRoute/TCPRoute/TLSRoute/UDPRoute that the binder needs in
+// order to evaluate it against a Gateway's listeners, independent of which concrete
+// route kind it came from.
+type Route struct {
+	types.NamespacedName
+
+	// Kind is the route's Kind, e.g. "HTTPRoute", used to check a listener's
+	// AllowedRoutes.Kinds allow-list.
+	Kind gatewayv1alpha2.Kind
+
+	// ParentRefs are the route's requested parents, filtered to those that could plausibly
+	// reference the Gateway being bound (callers need not pre-filter, Bind ignores refs
+	// for other gateways).
+	ParentRefs []gatewayv1alpha2.ParentReference
+
+	// Hostnames is the route's requested hostnames, if the route kind supports them
+	// (HTTPRoute, TLSRoute). Route kinds without hostnames (TCPRoute, UDPRoute) leave
+	// this nil.
+	Hostnames []gatewayv1alpha2.Hostname
+
+	// CrossNamespaceBackendRefs lists the namespaces of any backendRefs that live outside
+	// Namespace, so the binder can check them against ReferenceGrants.
+	CrossNamespaceBackendRefs []string
+}
+
+// ReferenceGrant is the minimal shape of a ReferenceGrant needed to check whether a
+// cross-namespace backendRef is permitted.
+type ReferenceGrant struct {
+	FromNamespace string
+	FromKind      gatewayv1alpha2.Kind
+	ToNamespace   string
+}
+
+// ParentStatus is the condition set to record for one of a route's parentRefs.
+type ParentStatus struct {
+	ParentRef  gatewayv1alpha2.ParentReference
+	Conditions []metav1.Condition
+}
+
+// RouteUpdate is the full set of parent statuses to apply to a single route's
+// Status.Parents.
+type RouteUpdate struct {
+	Route   types.NamespacedName
+	Kind    gatewayv1alpha2.Kind
+	Parents []ParentStatus
+}
+
+// ListenerUpdate is the AttachedRoutes count and any gateway-level conditions (e.g.
+// Conflicted) computed for a single listener.
+type ListenerUpdate struct {
+	Name           gatewayv1alpha2.SectionName
+	AttachedRoutes int32
+	Conditions     []metav1.Condition
+}
+
+// BindResult is the outcome of a single Bind call: every route and listener status
+// update that resulted from evaluating the given routes against the given listeners.
+// Callers apply Routes and Listeners together so that AttachedRoutes counts never lag
+// behind the route conditions that produced them.
+type BindResult struct {
+	Routes    []RouteUpdate
+	Listeners []ListenerUpdate
+}
+
+// Bind evaluates every route in routes against every listener in listeners and returns
+// the resulting BindResult. gateway identifies the Gateway being bound, used to decide
+// whether a given parentRef actually targets it. namespaceLabels maps a namespace name
+// to its labels, used to evaluate allowedRoutes.namespaces selectors. grants is the set
+// of ReferenceGrants in scope, used to permit cross-namespace backendRefs.
+//
+// Bind does not mutate any of its arguments.
+func Bind(
+	gateway types.NamespacedName,
+	listeners []gatewayv1alpha2.Listener,
+	routes []Route,
+	grants []ReferenceGrant,
+	namespaceLabels map[string]map[string]string,
+) BindResult {
+	attached := make(map[gatewayv1alpha2.SectionName]int32, len(listeners))
+	routeUpdates := make([]RouteUpdate, 0, len(routes))
+
+	for _, route := range routes {
+		var parents []ParentStatus
+
+		for _, parentRef := range route.ParentRefs {
+			if !parentRefTargetsGateway(parentRef, gateway, route.Namespace) {
+				continue
+			}
+
+			candidates := candidateListeners(listeners, parentRef)
+			if len(candidates) == 0 {
+				parents = append(parents, ParentStatus{
+					ParentRef:  parentRef,
+					Conditions: []metav1.Condition{noMatchingParentCondition()},
+				})
+				continue
+			}
+
+			boundAny := false
+			var conditions []metav1.Condition
+			for _, listener := range candidates {
+				cond, bound := evaluateListener(gateway.Namespace, listener, route, grants, namespaceLabels)
+				conditions = append(conditions, cond...)
+				if bound {
+					boundAny = true
+					attached[listener.Name]++
+				}
+			}
+			conditions = append(conditions, resolvedRefsCondition(route, grants))
+			if boundAny {
+				conditions = append(conditions, acceptedCondition())
+			}
+
+			parents = append(parents, ParentStatus{ParentRef: parentRef, Conditions: conditions})
+		}
+
+		if len(parents) > 0 {
+			routeUpdates = append(routeUpdates, RouteUpdate{Route: route.NamespacedName, Kind: route.Kind, Parents: parents})
+		}
+	}
+
+	listenerUpdates := make([]ListenerUpdate, 0, len(listeners))
+	conflicts := detectConflicts(listeners)
+	for _, listener := range listeners {
+		listenerUpdates = append(listenerUpdates, ListenerUpdate{
+			Name:           listener.Name,
+			AttachedRoutes: attached[listener.Name],
+			Conditions:     conflicts[listener.Name],
+		})
+	}
+
+	return BindResult{Routes: routeUpdates, Listeners: listenerUpdates}
+}
+
+// parentRefTargetsGateway reports whether parentRef refers to gateway, resolving the
+// parentRef's namespace against routeNamespace per the Gateway API default-to-same-
+// namespace rule.
+func parentRefTargetsGateway(parentRef gatewayv1alpha2.ParentReference, gateway types.NamespacedName, routeNamespace string) bool {
+	namespace := routeNamespace
+	if parentRef.Namespace != nil {
+		namespace = string(*parentRef.Namespace)
+	}
+	return namespace == gateway.Namespace && string(parentRef.Name) == gateway.Name
+}
+
+// candidateListeners narrows listeners down to the ones a parentRef could possibly bind
+// to, honoring an explicit SectionName or Port when set.
+func candidateListeners(listeners []gatewayv1alpha2.Listener, parentRef gatewayv1alpha2.ParentReference) []gatewayv1alpha2.Listener {
+	var candidates []gatewayv1alpha2.Listener
+	for _, listener := range listeners {
+		if parentRef.SectionName != nil && *parentRef.SectionName != listener.Name {
+			continue
+		}
+		if parentRef.Port != nil && *parentRef.Port != listener.Port {
+			continue
+		}
+		candidates = append(candidates, listener)
+	}
+	return candidates
+}
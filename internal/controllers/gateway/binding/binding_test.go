@@ -0,0 +1,208 @@
+package binding
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+func listenerStatusFor(result BindResult, name gatewayv1alpha2.SectionName) (ListenerUpdate, bool) {
+	for _, update := range result.Listeners {
+		if update.Name == name {
+			return update, true
+		}
+	}
+	return ListenerUpdate{}, false
+}
+
+func conditionFor(conditions []metav1.Condition, conditionType string) (metav1.Condition, bool) {
+	for _, c := range conditions {
+		if c.Type == conditionType {
+			return c, true
+		}
+	}
+	return metav1.Condition{}, false
+}
+
+func parentRef(gatewayName string) gatewayv1alpha2.ParentReference {
+	return gatewayv1alpha2.ParentReference{Name: gatewayv1alpha2.ObjectName(gatewayName)}
+}
+
+func TestBindAttachesMatchingRoute(t *testing.T) {
+	gateway := types.NamespacedName{Namespace: "default", Name: "my-gateway"}
+	listeners := []gatewayv1alpha2.Listener{
+		{Name: "http", Port: 80, Protocol: gatewayv1alpha2.HTTPProtocolType},
+	}
+	routes := []Route{
+		{
+			NamespacedName: types.NamespacedName{Namespace: "default", Name: "my-route"},
+			Kind:           "HTTPRoute",
+			ParentRefs:     []gatewayv1alpha2.ParentReference{parentRef("my-gateway")},
+		},
+	}
+
+	result := Bind(gateway, listeners, routes, nil, nil)
+
+	httpListener, ok := listenerStatusFor(result, "http")
+	if !ok {
+		t.Fatal("expected a listener update for \"http\"")
+	}
+	if httpListener.AttachedRoutes != 1 {
+		t.Errorf("AttachedRoutes = %d, want 1", httpListener.AttachedRoutes)
+	}
+
+	if len(result.Routes) != 1 {
+		t.Fatalf("len(result.Routes) = %d, want 1", len(result.Routes))
+	}
+	if len(result.Routes[0].Parents) != 1 {
+		t.Fatalf("len(Parents) = %d, want 1", len(result.Routes[0].Parents))
+	}
+
+	accepted, ok := conditionFor(result.Routes[0].Parents[0].Conditions, string(gatewayv1alpha2.RouteConditionAccepted))
+	if !ok {
+		t.Fatal("expected an Accepted condition on the route's parent status")
+	}
+	if accepted.Status != metav1.ConditionTrue {
+		t.Errorf("Accepted condition Status = %v, want True", accepted.Status)
+	}
+}
+
+func TestBindRejectsNamespaceNotAllowed(t *testing.T) {
+	gateway := types.NamespacedName{Namespace: "default", Name: "my-gateway"}
+	fromSame := gatewayv1alpha2.NamespacesFromSame
+	listeners := []gatewayv1alpha2.Listener{
+		{
+			Name:     "http",
+			Port:     80,
+			Protocol: gatewayv1alpha2.HTTPProtocolType,
+			AllowedRoutes: &gatewayv1alpha2.AllowedRoutes{
+				Namespaces: &gatewayv1alpha2.RouteNamespaces{From: &fromSame},
+			},
+		},
+	}
+	routes := []Route{
+		{
+			NamespacedName: types.NamespacedName{Namespace: "other-ns", Name: "my-route"},
+			Kind:           "HTTPRoute",
+			ParentRefs:     []gatewayv1alpha2.ParentReference{parentRef("my-gateway")},
+		},
+	}
+
+	result := Bind(gateway, listeners, routes, nil, map[string]map[string]string{})
+
+	httpListener, _ := listenerStatusFor(result, "http")
+	if httpListener.AttachedRoutes != 0 {
+		t.Errorf("AttachedRoutes = %d, want 0 for a route outside the allowed namespace", httpListener.AttachedRoutes)
+	}
+
+	accepted, ok := conditionFor(result.Routes[0].Parents[0].Conditions, string(gatewayv1alpha2.RouteConditionAccepted))
+	if !ok {
+		t.Fatal("expected an Accepted condition on the route's parent status")
+	}
+	if accepted.Status != metav1.ConditionFalse {
+		t.Errorf("Accepted condition Status = %v, want False", accepted.Status)
+	}
+	if accepted.Reason != string(gatewayv1alpha2.RouteReasonNotAllowedByListeners) {
+		t.Errorf("Accepted condition Reason = %q, want %q", accepted.Reason, gatewayv1alpha2.RouteReasonNotAllowedByListeners)
+	}
+}
+
+func TestBindRejectsHostnameMismatch(t *testing.T) {
+	gateway := types.NamespacedName{Namespace: "default", Name: "my-gateway"}
+	listenerHostname := gatewayv1alpha2.Hostname("foo.example.com")
+	listeners := []gatewayv1alpha2.Listener{
+		{Name: "http", Port: 80, Protocol: gatewayv1alpha2.HTTPProtocolType, Hostname: &listenerHostname},
+	}
+	routes := []Route{
+		{
+			NamespacedName: types.NamespacedName{Namespace: "default", Name: "my-route"},
+			Kind:           "HTTPRoute",
+			ParentRefs:     []gatewayv1alpha2.ParentReference{parentRef("my-gateway")},
+			Hostnames:      []gatewayv1alpha2.Hostname{"bar.example.com"},
+		},
+	}
+
+	result := Bind(gateway, listeners, routes, nil, nil)
+
+	httpListener, _ := listenerStatusFor(result, "http")
+	if httpListener.AttachedRoutes != 0 {
+		t.Errorf("AttachedRoutes = %d, want 0 for a non-overlapping hostname", httpListener.AttachedRoutes)
+	}
+
+	accepted, ok := conditionFor(result.Routes[0].Parents[0].Conditions, string(gatewayv1alpha2.RouteConditionAccepted))
+	if !ok {
+		t.Fatal("expected an Accepted condition on the route's parent status")
+	}
+	if accepted.Reason != string(gatewayv1alpha2.RouteReasonNoMatchingListenerHostname) {
+		t.Errorf("Accepted condition Reason = %q, want %q", accepted.Reason, gatewayv1alpha2.RouteReasonNoMatchingListenerHostname)
+	}
+}
+
+func TestBindIgnoresRouteForOtherGateway(t *testing.T) {
+	gateway := types.NamespacedName{Namespace: "default", Name: "my-gateway"}
+	listeners := []gatewayv1alpha2.Listener{
+		{Name: "http", Port: 80, Protocol: gatewayv1alpha2.HTTPProtocolType},
+	}
+	routes := []Route{
+		{
+			NamespacedName: types.NamespacedName{Namespace: "default", Name: "my-route"},
+			Kind:           "HTTPRoute",
+			ParentRefs:     []gatewayv1alpha2.ParentReference{parentRef("someone-elses-gateway")},
+		},
+	}
+
+	result := Bind(gateway, listeners, routes, nil, nil)
+
+	if len(result.Routes) != 0 {
+		t.Errorf("len(result.Routes) = %d, want 0 for a route that doesn't reference this gateway", len(result.Routes))
+	}
+	httpListener, _ := listenerStatusFor(result, "http")
+	if httpListener.AttachedRoutes != 0 {
+		t.Errorf("AttachedRoutes = %d, want 0", httpListener.AttachedRoutes)
+	}
+}
+
+func TestBindDetectsListenerConflict(t *testing.T) {
+	gateway := types.NamespacedName{Namespace: "default", Name: "my-gateway"}
+	listeners := []gatewayv1alpha2.Listener{
+		{Name: "http", Port: 80, Protocol: gatewayv1alpha2.HTTPProtocolType},
+		{Name: "tcp", Port: 80, Protocol: gatewayv1alpha2.TCPProtocolType},
+	}
+
+	result := Bind(gateway, listeners, nil, nil, nil)
+
+	for _, name := range []gatewayv1alpha2.SectionName{"http", "tcp"} {
+		update, ok := listenerStatusFor(result, name)
+		if !ok {
+			t.Fatalf("expected a listener update for %q", name)
+		}
+		conflicted, ok := conditionFor(update.Conditions, string(gatewayv1alpha2.ListenerConditionConflicted))
+		if !ok {
+			t.Fatalf("expected a Conflicted condition for listener %q", name)
+		}
+		if conflicted.Status != metav1.ConditionTrue {
+			t.Errorf("listener %q Conflicted = %v, want True", name, conflicted.Status)
+		}
+	}
+}
+
+func TestBindReportsNoConflictForCompatibleListeners(t *testing.T) {
+	gateway := types.NamespacedName{Namespace: "default", Name: "my-gateway"}
+	listeners := []gatewayv1alpha2.Listener{
+		{Name: "http", Port: 80, Protocol: gatewayv1alpha2.HTTPProtocolType},
+		{Name: "https", Port: 443, Protocol: gatewayv1alpha2.HTTPSProtocolType},
+	}
+
+	result := Bind(gateway, listeners, nil, nil, nil)
+
+	httpUpdate, _ := listenerStatusFor(result, "http")
+	conflicted, ok := conditionFor(httpUpdate.Conditions, string(gatewayv1alpha2.ListenerConditionConflicted))
+	if !ok {
+		t.Fatal("expected a Conflicted condition even when there is no conflict")
+	}
+	if conflicted.Status != metav1.ConditionFalse {
+		t.Errorf("Conflicted = %v, want False for listeners on different ports", conflicted.Status)
+	}
+}
@@ -0,0 +1,62 @@
+package binding
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// detectConflicts looks for pairs of listeners sharing a port but disagreeing on
+// protocol or hostname, which the Gateway API requires to be reported via the
+// listener's Conflicted condition. It returns a Conflicted condition for every listener,
+// keyed by listener name: True for listeners actually in conflict, False otherwise. A
+// caller that feeds this straight into a ConditionSet therefore sees a conflict clear
+// itself on the reconcile after it's resolved, instead of a stale True condition
+// lingering because nothing told the ConditionSet to overwrite it.
+func detectConflicts(listeners []gatewayv1alpha2.Listener) map[gatewayv1alpha2.SectionName][]metav1.Condition {
+	conflicted := make(map[gatewayv1alpha2.SectionName]bool, len(listeners))
+
+	for i := range listeners {
+		for j := range listeners {
+			if i == j {
+				continue
+			}
+			a, b := listeners[i], listeners[j]
+			if a.Port != b.Port {
+				continue
+			}
+			if a.Protocol != b.Protocol || !sameHostname(a.Hostname, b.Hostname) {
+				conflicted[a.Name] = true
+				conflicted[b.Name] = true
+			}
+		}
+	}
+
+	conditions := make(map[gatewayv1alpha2.SectionName][]metav1.Condition, len(listeners))
+	for _, listener := range listeners {
+		if conflicted[listener.Name] {
+			conditions[listener.Name] = []metav1.Condition{{
+				Type:               string(gatewayv1alpha2.ListenerConditionConflicted),
+				Status:             metav1.ConditionTrue,
+				LastTransitionTime: metav1.Now(),
+				Reason:             string(gatewayv1alpha2.ListenerReasonProtocolConflict),
+				Message:            "this listener shares a port with another listener that uses a different protocol or hostname",
+			}}
+			continue
+		}
+		conditions[listener.Name] = []metav1.Condition{{
+			Type:               string(gatewayv1alpha2.ListenerConditionConflicted),
+			Status:             metav1.ConditionFalse,
+			LastTransitionTime: metav1.Now(),
+			Reason:             string(gatewayv1alpha2.ListenerReasonNoConflicts),
+			Message:            "this listener has no conflicts with other listeners on the gateway",
+		}}
+	}
+	return conditions
+}
+
+func sameHostname(a, b *gatewayv1alpha2.Hostname) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
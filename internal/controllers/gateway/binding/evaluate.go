@@ -0,0 +1,201 @@
+package binding
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// evaluateListener checks route against a single candidate listener, returning the
+// conditions that should be recorded for that attempt and whether the route actually
+// bound to the listener. At most one condition is returned per listener (the first
+// check that fails wins); the caller aggregates them across all candidate listeners for
+// the parentRef.
+func evaluateListener(
+	gatewayNamespace string,
+	listener gatewayv1alpha2.Listener,
+	route Route,
+	grants []ReferenceGrant,
+	namespaceLabels map[string]map[string]string,
+) ([]metav1.Condition, bool) {
+	if !kindAllowed(listener, route.Kind) {
+		return nil, false
+	}
+
+	if !namespaceAllowed(listener, route.Namespace, gatewayNamespace, namespaceLabels) {
+		return []metav1.Condition{{
+			Type:               string(gatewayv1alpha2.RouteConditionAccepted),
+			Status:             metav1.ConditionFalse,
+			LastTransitionTime: metav1.Now(),
+			Reason:             string(gatewayv1alpha2.RouteReasonNotAllowedByListeners),
+			Message:            fmt.Sprintf("listener %s does not allow routes from namespace %s", listener.Name, route.Namespace),
+		}}, false
+	}
+
+	if requiresHostnameMatch(route.Kind) {
+		if len(intersectHostnames(listenerHostnames(listener), route.Hostnames)) == 0 && len(route.Hostnames) > 0 {
+			return []metav1.Condition{{
+				Type:               string(gatewayv1alpha2.RouteConditionAccepted),
+				Status:             metav1.ConditionFalse,
+				LastTransitionTime: metav1.Now(),
+				Reason:             string(gatewayv1alpha2.RouteReasonNoMatchingListenerHostname),
+				Message:            fmt.Sprintf("no hostname in %v matches listener %s", route.Hostnames, listener.Name),
+			}}, false
+		}
+	}
+
+	return nil, true
+}
+
+// kindAllowed reports whether the listener's AllowedRoutes.Kinds includes routeKind. A
+// listener with no AllowedRoutes.Kinds accepts every route kind; the kinds implied by the
+// listener's protocol are not checked.
+func kindAllowed(listener gatewayv1alpha2.Listener, routeKind gatewayv1alpha2.Kind) bool {
+	if listener.AllowedRoutes == nil || len(listener.AllowedRoutes.Kinds) == 0 {
+		return true
+	}
+	for _, kind := range listener.AllowedRoutes.Kinds {
+		if kind.Kind == routeKind {
+			return true
+		}
+	}
+	return false
+}
+
+// namespaceAllowed evaluates a listener's AllowedRoutes.Namespaces selector against the
+// labels of routeNamespace. The Gateway API default for an unset AllowedRoutes (or an
+// unset Namespaces within it) is {namespaces:{from: Same}}, not "from: All", so a
+// listener with no allowedRoutes configured only accepts routes from its own namespace.
+func namespaceAllowed(listener gatewayv1alpha2.Listener, routeNamespace, gatewayNamespace string, namespaceLabels map[string]map[string]string) bool {
+	if listener.AllowedRoutes == nil || listener.AllowedRoutes.Namespaces == nil {
+		return routeNamespace == gatewayNamespace
+	}
+
+	switch from := listener.AllowedRoutes.Namespaces.From; {
+	case from == nil || *from == gatewayv1alpha2.NamespacesFromSame:
+		return routeNamespace == gatewayNamespace
+	case *from == gatewayv1alpha2.NamespacesFromAll:
+		return true
+	case *from == gatewayv1alpha2.NamespacesFromSelector:
+		if listener.AllowedRoutes.Namespaces.Selector == nil {
+			return false
+		}
+		return labelsMatchSelector(namespaceLabels[routeNamespace], listener.AllowedRoutes.Namespaces.Selector)
+	default:
+		return false
+	}
+}
+
+// labelsMatchSelector reports whether labels satisfies every matchLabels entry and
+// matchExpressions clause in selector. It implements the common "In"/"NotIn"/"Exists"/
+// "DoesNotExist" operators; unknown operators do not match.
+func labelsMatchSelector(labels map[string]string, selector *metav1.LabelSelector) bool {
+	for key, value := range selector.MatchLabels {
+		if labels[key] != value {
+			return false
+		}
+	}
+
+	for _, expr := range selector.MatchExpressions {
+		value, exists := labels[expr.Key]
+		switch expr.Operator {
+		case metav1.LabelSelectorOpIn:
+			if !exists || !contains(expr.Values, value) {
+				return false
+			}
+		case metav1.LabelSelectorOpNotIn:
+			if exists && contains(expr.Values, value) {
+				return false
+			}
+		case metav1.LabelSelectorOpExists:
+			if !exists {
+				return false
+			}
+		case metav1.LabelSelectorOpDoesNotExist:
+			if exists {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+func contains(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
+
+// requiresHostnameMatch reports whether routes of the given kind carry hostnames that
+// must intersect with a listener's hostname to bind (HTTPRoute, TLSRoute); TCPRoute and
+// UDPRoute have no hostname field and always pass this check.
+func requiresHostnameMatch(kind gatewayv1alpha2.Kind) bool {
+	return kind == "HTTPRoute" || kind == "TLSRoute"
+}
+
+// listenerHostnames returns the single hostname configured on a listener as a one-
+// element slice, or an empty slice if the listener has no hostname restriction.
+func listenerHostnames(listener gatewayv1alpha2.Listener) []gatewayv1alpha2.Hostname {
+	if listener.Hostname == nil {
+		return nil
+	}
+	return []gatewayv1alpha2.Hostname{*listener.Hostname}
+}
+
+// resolvedRefsCondition reports whether every cross-namespace backendRef on route is
+// permitted by a ReferenceGrant.
+func resolvedRefsCondition(route Route, grants []ReferenceGrant) metav1.Condition {
+	for _, toNamespace := range route.CrossNamespaceBackendRefs {
+		if !grantPermits(grants, route.Namespace, route.Kind, toNamespace) {
+			return metav1.Condition{
+				Type:               string(gatewayv1alpha2.RouteConditionResolvedRefs),
+				Status:             metav1.ConditionFalse,
+				LastTransitionTime: metav1.Now(),
+				Reason:             string(gatewayv1alpha2.RouteReasonRefNotPermitted),
+				Message:            fmt.Sprintf("backendRef in namespace %s is not permitted by any ReferenceGrant", toNamespace),
+			}
+		}
+	}
+
+	return metav1.Condition{
+		Type:               string(gatewayv1alpha2.RouteConditionResolvedRefs),
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             string(gatewayv1alpha2.RouteReasonResolvedRefs),
+	}
+}
+
+func grantPermits(grants []ReferenceGrant, fromNamespace string, fromKind gatewayv1alpha2.Kind, toNamespace string) bool {
+	for _, grant := range grants {
+		if grant.FromNamespace == fromNamespace && grant.FromKind == fromKind && grant.ToNamespace == toNamespace {
+			return true
+		}
+	}
+	return false
+}
+
+func noMatchingParentCondition() metav1.Condition {
+	return metav1.Condition{
+		Type:               string(gatewayv1alpha2.RouteConditionAccepted),
+		Status:             metav1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             string(gatewayv1alpha2.RouteReasonNoMatchingParent),
+		Message:            "no listener on the referenced gateway matches this parentRef's sectionName/port",
+	}
+}
+
+func acceptedCondition() metav1.Condition {
+	return metav1.Condition{
+		Type:               string(gatewayv1alpha2.RouteConditionAccepted),
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             string(gatewayv1alpha2.RouteReasonAccepted),
+	}
+}
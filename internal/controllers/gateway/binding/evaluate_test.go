@@ -0,0 +1,113 @@
+package binding
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+func TestNamespaceAllowedDefaultsToSame(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		listener gatewayv1alpha2.Listener
+		want     bool
+	}{
+		{
+			name:     "nil AllowedRoutes defaults to same-namespace-only",
+			listener: gatewayv1alpha2.Listener{},
+			want:     false,
+		},
+		{
+			name:     "AllowedRoutes set but Namespaces nil defaults to same-namespace-only",
+			listener: gatewayv1alpha2.Listener{AllowedRoutes: &gatewayv1alpha2.AllowedRoutes{}},
+			want:     false,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := namespaceAllowed(tt.listener, "other-ns", "gateway-ns", nil); got != tt.want {
+				t.Errorf("namespaceAllowed() = %v, want %v for a route in a different namespace", got, tt.want)
+			}
+		})
+	}
+
+	listener := gatewayv1alpha2.Listener{}
+	if !namespaceAllowed(listener, "same-ns", "same-ns", nil) {
+		t.Error("namespaceAllowed() = false, want true for a route in the gateway's own namespace with no AllowedRoutes configured")
+	}
+}
+
+func TestLabelsMatchSelector(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		labels   map[string]string
+		selector *metav1.LabelSelector
+		want     bool
+	}{
+		{
+			name:     "matchLabels all present",
+			labels:   map[string]string{"team": "platform", "env": "prod"},
+			selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "platform"}},
+			want:     true,
+		},
+		{
+			name:     "matchLabels missing key",
+			labels:   map[string]string{"env": "prod"},
+			selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "platform"}},
+			want:     false,
+		},
+		{
+			name:   "matchExpressions In satisfied",
+			labels: map[string]string{"env": "staging"},
+			selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "env", Operator: metav1.LabelSelectorOpIn, Values: []string{"staging", "prod"}},
+			}},
+			want: true,
+		},
+		{
+			name:   "matchExpressions NotIn violated",
+			labels: map[string]string{"env": "prod"},
+			selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "env", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"prod"}},
+			}},
+			want: false,
+		},
+		{
+			name:   "matchExpressions Exists satisfied",
+			labels: map[string]string{"env": "prod"},
+			selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "env", Operator: metav1.LabelSelectorOpExists},
+			}},
+			want: true,
+		},
+		{
+			name:   "matchExpressions DoesNotExist violated",
+			labels: map[string]string{"env": "prod"},
+			selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "env", Operator: metav1.LabelSelectorOpDoesNotExist},
+			}},
+			want: false,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := labelsMatchSelector(tt.labels, tt.selector); got != tt.want {
+				t.Errorf("labelsMatchSelector(%v, %+v) = %v, want %v", tt.labels, tt.selector, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequiresHostnameMatch(t *testing.T) {
+	if !requiresHostnameMatch("HTTPRoute") {
+		t.Error("HTTPRoute should require a hostname match")
+	}
+	if !requiresHostnameMatch("TLSRoute") {
+		t.Error("TLSRoute should require a hostname match")
+	}
+	if requiresHostnameMatch("TCPRoute") {
+		t.Error("TCPRoute has no hostnames and should not require a hostname match")
+	}
+	if requiresHostnameMatch("UDPRoute") {
+		t.Error("UDPRoute has no hostnames and should not require a hostname match")
+	}
+}
@@ -0,0 +1,70 @@
+package binding
+
+import (
+	"strings"
+
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// intersectHostnames returns, for every pair of a route hostname and a listener hostname
+// that overlap, the more specific of the two (see matchHostname), per the RFC 1123
+// wildcard matching rules defined by the Gateway API (a single leading "*." label matches
+// one or more labels, but not the bare suffix itself). If
+// listenerHostnames is empty, the listener places no hostname restriction and every
+// route hostname is returned unchanged. If routeHostnames is empty, the route places no
+// restriction of its own and every listener hostname is returned.
+func intersectHostnames(listenerHostnames, routeHostnames []gatewayv1alpha2.Hostname) []gatewayv1alpha2.Hostname {
+	if len(listenerHostnames) == 0 {
+		return routeHostnames
+	}
+	if len(routeHostnames) == 0 {
+		return listenerHostnames
+	}
+
+	var matches []gatewayv1alpha2.Hostname
+	for _, routeHostname := range routeHostnames {
+		for _, listenerHostname := range listenerHostnames {
+			if match, ok := matchHostname(string(listenerHostname), string(routeHostname)); ok {
+				matches = append(matches, gatewayv1alpha2.Hostname(match))
+			}
+		}
+	}
+	return matches
+}
+
+// matchHostname compares a listener hostname and a route hostname, either of which may
+// carry a leading wildcard label ("*.example.com"), and returns the more specific of the
+// two plus whether they overlap at all.
+func matchHostname(a, b string) (string, bool) {
+	if a == b {
+		return a, true
+	}
+
+	aWild, aSuffix := splitWildcard(a)
+	bWild, bSuffix := splitWildcard(b)
+
+	switch {
+	case aWild && bWild:
+		if aSuffix == bSuffix {
+			return a, true
+		}
+		return "", false
+	case aWild:
+		if strings.HasSuffix(b, aSuffix) && b != aSuffix {
+			return b, true
+		}
+	case bWild:
+		if strings.HasSuffix(a, bSuffix) && a != bSuffix {
+			return a, true
+		}
+	}
+
+	return "", false
+}
+
+func splitWildcard(hostname string) (isWildcard bool, suffix string) {
+	if strings.HasPrefix(hostname, "*.") {
+		return true, strings.TrimPrefix(hostname, "*")
+	}
+	return false, hostname
+}
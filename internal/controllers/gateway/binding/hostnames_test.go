@@ -0,0 +1,102 @@
+package binding
+
+import (
+	"reflect"
+	"testing"
+
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+func hostnames(values ...string) []gatewayv1alpha2.Hostname {
+	out := make([]gatewayv1alpha2.Hostname, 0, len(values))
+	for _, v := range values {
+		out = append(out, gatewayv1alpha2.Hostname(v))
+	}
+	return out
+}
+
+func TestIntersectHostnames(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		listener  []gatewayv1alpha2.Hostname
+		route     []gatewayv1alpha2.Hostname
+		wantCount int
+	}{
+		{
+			name:      "exact match",
+			listener:  hostnames("example.com"),
+			route:     hostnames("example.com"),
+			wantCount: 1,
+		},
+		{
+			name:      "listener wildcard covers route hostname",
+			listener:  hostnames("*.example.com"),
+			route:     hostnames("foo.example.com"),
+			wantCount: 1,
+		},
+		{
+			name:      "route wildcard covers listener hostname",
+			listener:  hostnames("foo.example.com"),
+			route:     hostnames("*.example.com"),
+			wantCount: 1,
+		},
+		{
+			name:      "wildcard does not match the bare suffix itself",
+			listener:  hostnames("*.example.com"),
+			route:     hostnames("example.com"),
+			wantCount: 0,
+		},
+		{
+			name:      "no listener restriction returns route hostnames unchanged",
+			listener:  nil,
+			route:     hostnames("example.com", "other.com"),
+			wantCount: 2,
+		},
+		{
+			name:      "no route restriction returns listener hostnames unchanged",
+			listener:  hostnames("example.com"),
+			route:     nil,
+			wantCount: 1,
+		},
+		{
+			name:      "disjoint hostnames match nothing",
+			listener:  hostnames("example.com"),
+			route:     hostnames("other.com"),
+			wantCount: 0,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := intersectHostnames(tt.listener, tt.route)
+			if len(got) != tt.wantCount {
+				t.Errorf("intersectHostnames(%v, %v) = %v, want %d match(es)", tt.listener, tt.route, got, tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestMatchHostname(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		a, b      string
+		wantMatch string
+		wantOK    bool
+	}{
+		{name: "identical", a: "example.com", b: "example.com", wantMatch: "example.com", wantOK: true},
+		{name: "wildcard a matches specific b", a: "*.example.com", b: "foo.example.com", wantMatch: "foo.example.com", wantOK: true},
+		{name: "wildcard b matches specific a", a: "foo.example.com", b: "*.example.com", wantMatch: "foo.example.com", wantOK: true},
+		{name: "both wildcard same suffix", a: "*.example.com", b: "*.example.com", wantMatch: "*.example.com", wantOK: true},
+		{name: "both wildcard different suffix", a: "*.example.com", b: "*.other.com", wantOK: false},
+		{name: "wildcard does not match bare suffix", a: "*.example.com", b: "example.com", wantOK: false},
+		{name: "disjoint", a: "example.com", b: "other.com", wantOK: false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := matchHostname(tt.a, tt.b)
+			if ok != tt.wantOK {
+				t.Fatalf("matchHostname(%q, %q) ok = %v, want %v", tt.a, tt.b, ok, tt.wantOK)
+			}
+			if ok && !reflect.DeepEqual(got, tt.wantMatch) {
+				t.Errorf("matchHostname(%q, %q) = %q, want %q", tt.a, tt.b, got, tt.wantMatch)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package gateway
+
+import (
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// allowedConditionTypes is the set of condition Types this controller ever sets on a
+// Gateway, its listeners, or the routes bound to it. ConditionSet silently drops any
+// condition outside this set, which both documents the controller's status surface in
+// one place and keeps a buggy caller from quietly filling up the 8-condition API limit
+// with something unexpected.
+var allowedConditionTypes = map[string]bool{
+	string(gatewayv1alpha2.GatewayConditionScheduled):     true,
+	string(gatewayv1alpha2.GatewayConditionAccepted):      true,
+	string(gatewayv1alpha2.GatewayConditionProgrammed):    true,
+	string(gatewayv1alpha2.GatewayConditionReady):         true,
+	string(gatewayv1alpha2.ListenerConditionReady):        true,
+	string(gatewayv1alpha2.ListenerConditionConflicted):   true,
+	string(gatewayv1alpha2.ListenerConditionDetached):     true,
+	string(gatewayv1alpha2.ListenerConditionResolvedRefs): true,
+	string(gatewayv1alpha2.RouteConditionAccepted):        true,
+	string(gatewayv1alpha2.RouteConditionResolvedRefs):    true,
+}
+
+// ConditionSet is a small condition manager used in place of the old fixed-size, tail-
+// trimmed []metav1.Condition slice. It dedupes by Type (the API only allows one
+// condition per Type per Kubernetes object/subresource anyway), preserves
+// LastTransitionTime when a Set call doesn't actually change Status, and always orders
+// its output deterministically by Type so that repeated reconciles of an unchanged
+// condition set produce an identical JSON patch instead of API-server churn.
+//
+// ConditionSet replaces the previous approach of simply keeping the last 8
+// conditions: that scheme could evict an old, still-relevant condition type (e.g.
+// Accepted) simply because the controller flapped between two Status values of some
+// other type often enough to fill the window.
+type ConditionSet struct {
+	conditions map[string]metav1.Condition
+}
+
+// NewConditionSet seeds a ConditionSet from a Gateway, listener, or route's existing
+// condition list, so that a subsequent Set call for an unchanged Status can recover the
+// original LastTransitionTime.
+func NewConditionSet(existing []metav1.Condition) *ConditionSet {
+	set := &ConditionSet{conditions: make(map[string]metav1.Condition, len(existing))}
+	for _, condition := range existing {
+		set.conditions[condition.Type] = condition
+	}
+	return set
+}
+
+// Set records condition, overwriting any existing condition of the same Type. If a
+// condition of that Type was already present with the same Status, LastTransitionTime is
+// carried forward from it rather than taking condition's own value, matching the
+// Kubernetes convention that LastTransitionTime only advances when Status actually
+// changes. Conditions whose Type isn't in allowedConditionTypes are dropped.
+func (c *ConditionSet) Set(condition metav1.Condition) {
+	if !allowedConditionTypes[condition.Type] {
+		return
+	}
+
+	if previous, ok := c.conditions[condition.Type]; ok && previous.Status == condition.Status {
+		condition.LastTransitionTime = previous.LastTransitionTime
+	}
+
+	c.conditions[condition.Type] = condition
+}
+
+// Conditions returns every condition currently in the set, ordered deterministically by
+// Type so that two ConditionSets with the same contents always serialize identically.
+func (c *ConditionSet) Conditions() []metav1.Condition {
+	conditions := make([]metav1.Condition, 0, len(c.conditions))
+	for _, condition := range c.conditions {
+		conditions = append(conditions, condition)
+	}
+	sort.Slice(conditions, func(i, j int) bool { return conditions[i].Type < conditions[j].Type })
+	return conditions
+}
@@ -0,0 +1,166 @@
+package gateway
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// -----------------------------------------------------------------------------
+// Gateway Utils - Static Addresses
+// -----------------------------------------------------------------------------
+
+// validateGatewayAddresses checks that every entry in addresses has a supported Type
+// (IPAddress or Hostname) and a Value that actually parses for that type, returning the
+// first error encountered.
+func validateGatewayAddresses(addresses []gatewayv1alpha2.GatewayAddress) error {
+	for _, address := range addresses {
+		if err := validateGatewayAddress(address); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateGatewayAddress(address gatewayv1alpha2.GatewayAddress) error {
+	addrType := gatewayv1alpha2.IPAddressType
+	if address.Type != nil {
+		addrType = *address.Type
+	}
+
+	switch addrType {
+	case gatewayv1alpha2.IPAddressType:
+		if net.ParseIP(address.Value) == nil {
+			return fmt.Errorf("address %q is not a valid IP address", address.Value)
+		}
+	case gatewayv1alpha2.HostnameAddressType:
+		if errs := validation.IsDNS1123Subdomain(address.Value); len(errs) > 0 {
+			return fmt.Errorf("address %q is not a valid hostname: %s", address.Value, strings.Join(errs, "; "))
+		}
+	default:
+		return fmt.Errorf("unsupported address type %q: only %s and %s are supported", addrType, gatewayv1alpha2.IPAddressType, gatewayv1alpha2.HostnameAddressType)
+	}
+
+	return nil
+}
+
+// applyStaticAddressesToPublishService programs the requested static addresses onto the
+// publish Service's spec (LoadBalancerIP for the first requested IP, ExternalIPs for any
+// additional ones) so that the underlying load-balancer implementation is asked to
+// honor them, and reports whether the Service was actually changed.
+func applyStaticAddressesToPublishService(svc *corev1.Service, addresses []gatewayv1alpha2.GatewayAddress) bool {
+	var loadBalancerIP string
+	var externalIPs []string
+
+	for _, address := range addresses {
+		addrType := gatewayv1alpha2.IPAddressType
+		if address.Type != nil {
+			addrType = *address.Type
+		}
+		if addrType != gatewayv1alpha2.IPAddressType {
+			continue
+		}
+		if loadBalancerIP == "" {
+			loadBalancerIP = address.Value
+			continue
+		}
+		externalIPs = append(externalIPs, address.Value)
+	}
+
+	changed := false
+	if svc.Spec.LoadBalancerIP != loadBalancerIP {
+		svc.Spec.LoadBalancerIP = loadBalancerIP
+		changed = true
+	}
+	if !reflect.DeepEqual(svc.Spec.ExternalIPs, externalIPs) {
+		svc.Spec.ExternalIPs = externalIPs
+		changed = true
+	}
+
+	return changed
+}
+
+// programmedAddressesFromService derives the Gateway.Status.Addresses that should be
+// reported from what the publish Service actually ended up with, so that status always
+// reflects what was programmed rather than just echoing back the request.
+func programmedAddressesFromService(svc *corev1.Service) []gatewayv1alpha2.GatewayAddress {
+	ipType := gatewayv1alpha2.IPAddressType
+	hostType := gatewayv1alpha2.HostnameAddressType
+
+	var addresses []gatewayv1alpha2.GatewayAddress
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			addresses = append(addresses, gatewayv1alpha2.GatewayAddress{Type: &ipType, Value: ingress.IP})
+		}
+		if ingress.Hostname != "" {
+			addresses = append(addresses, gatewayv1alpha2.GatewayAddress{Type: &hostType, Value: ingress.Hostname})
+		}
+	}
+	return addresses
+}
+
+// gatewayAddressNotUsableCondition builds the Programmed=False condition to set when the
+// requested static addresses were rejected or could not be honored by the underlying
+// publish Service.
+func gatewayAddressNotUsableCondition(gateway *gatewayv1alpha2.Gateway, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:               string(gatewayv1alpha2.GatewayConditionProgrammed),
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: gateway.Generation,
+		LastTransitionTime: metav1.Now(),
+		Reason:             "AddressNotUsable",
+		Message:            message,
+	}
+}
+
+// gatewayAddressUsableCondition builds the Programmed=True condition to set once the
+// requested static addresses have been validated and programmed onto the publish
+// Service, clearing any previously-set Programmed=False/AddressNotUsable condition.
+func gatewayAddressUsableCondition(gateway *gatewayv1alpha2.Gateway) metav1.Condition {
+	return metav1.Condition{
+		Type:               string(gatewayv1alpha2.GatewayConditionProgrammed),
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: gateway.Generation,
+		LastTransitionTime: metav1.Now(),
+		Reason:             string(gatewayv1alpha2.GatewayReasonProgrammed),
+		Message:            "the requested static addresses were programmed onto the publish service",
+	}
+}
+
+// isPublishServiceAddressEventRelevant is a filter function, analogous to
+// isGatewayClassEventInClass, that reports whether a watch event on the shared publish
+// Service changed anything address-related (its requested LoadBalancerIP/ExternalIPs or
+// its actual LoadBalancer status) and therefore warrants reconciling the Gateways that
+// depend on it. Events that only touch unrelated Service fields are filtered out to
+// avoid needless Gateway reconciles.
+func isPublishServiceAddressEventRelevant(log logr.Logger, watchEvent interface{}) bool {
+	switch e := watchEvent.(type) {
+	case event.CreateEvent, event.DeleteEvent, event.GenericEvent:
+		return true
+	case event.UpdateEvent:
+		oldSvc, ok := e.ObjectOld.(*corev1.Service)
+		if !ok {
+			log.Error(fmt.Errorf("invalid type"), "received invalid object type in event handlers", "expected", "Service", "found", reflect.TypeOf(e.ObjectOld))
+			return false
+		}
+		newSvc, ok := e.ObjectNew.(*corev1.Service)
+		if !ok {
+			log.Error(fmt.Errorf("invalid type"), "received invalid object type in event handlers", "expected", "Service", "found", reflect.TypeOf(e.ObjectNew))
+			return false
+		}
+		return oldSvc.Spec.LoadBalancerIP != newSvc.Spec.LoadBalancerIP ||
+			!reflect.DeepEqual(oldSvc.Spec.ExternalIPs, newSvc.Spec.ExternalIPs) ||
+			!reflect.DeepEqual(oldSvc.Status.LoadBalancer, newSvc.Status.LoadBalancer)
+	default:
+		log.Error(fmt.Errorf("invalid type"), "received invalid event type in event handlers", "found", reflect.TypeOf(watchEvent))
+		return false
+	}
+}
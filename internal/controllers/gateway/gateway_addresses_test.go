@@ -0,0 +1,149 @@
+package gateway
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+func gatewayAddress(addrType gatewayv1alpha2.AddressType, value string) gatewayv1alpha2.GatewayAddress {
+	return gatewayv1alpha2.GatewayAddress{Type: &addrType, Value: value}
+}
+
+func TestValidateGatewayAddresses(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		addresses []gatewayv1alpha2.GatewayAddress
+		wantErr   bool
+	}{
+		{
+			name:      "valid IP address",
+			addresses: []gatewayv1alpha2.GatewayAddress{gatewayAddress(gatewayv1alpha2.IPAddressType, "10.0.0.1")},
+		},
+		{
+			name:      "valid hostname address",
+			addresses: []gatewayv1alpha2.GatewayAddress{gatewayAddress(gatewayv1alpha2.HostnameAddressType, "lb.example.com")},
+		},
+		{
+			name:      "malformed IP address",
+			addresses: []gatewayv1alpha2.GatewayAddress{gatewayAddress(gatewayv1alpha2.IPAddressType, "not-an-ip")},
+			wantErr:   true,
+		},
+		{
+			name:      "empty hostname value",
+			addresses: []gatewayv1alpha2.GatewayAddress{gatewayAddress(gatewayv1alpha2.HostnameAddressType, "")},
+			wantErr:   true,
+		},
+		{
+			name:      "unsupported address type",
+			addresses: []gatewayv1alpha2.GatewayAddress{gatewayAddress(gatewayv1alpha2.AddressType("NamedAddress"), "some-name")},
+			wantErr:   true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateGatewayAddresses(tt.addresses)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateGatewayAddresses(%v) error = %v, wantErr %v", tt.addresses, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApplyStaticAddressesToPublishService(t *testing.T) {
+	svc := &corev1.Service{}
+	addresses := []gatewayv1alpha2.GatewayAddress{
+		gatewayAddress(gatewayv1alpha2.IPAddressType, "10.0.0.1"),
+		gatewayAddress(gatewayv1alpha2.IPAddressType, "10.0.0.2"),
+	}
+
+	if changed := applyStaticAddressesToPublishService(svc, addresses); !changed {
+		t.Fatal("expected the first apply to report a change")
+	}
+	if svc.Spec.LoadBalancerIP != "10.0.0.1" {
+		t.Errorf("LoadBalancerIP = %q, want %q", svc.Spec.LoadBalancerIP, "10.0.0.1")
+	}
+	if !reflect.DeepEqual(svc.Spec.ExternalIPs, []string{"10.0.0.2"}) {
+		t.Errorf("ExternalIPs = %v, want %v", svc.Spec.ExternalIPs, []string{"10.0.0.2"})
+	}
+
+	if changed := applyStaticAddressesToPublishService(svc, addresses); changed {
+		t.Error("re-applying the same addresses should report no change")
+	}
+}
+
+// TestProgrammedAddressesFromServiceMismatch covers the case where the Service's actual
+// LoadBalancer ingress doesn't match what was requested on the Gateway (e.g. the cloud
+// provider assigned a different IP than the one set on Spec.LoadBalancerIP). Status must
+// reflect what the Service actually ended up with, not the request.
+func TestProgrammedAddressesFromServiceMismatch(t *testing.T) {
+	requested := []gatewayv1alpha2.GatewayAddress{gatewayAddress(gatewayv1alpha2.IPAddressType, "10.0.0.1")}
+
+	svc := &corev1.Service{}
+	if !applyStaticAddressesToPublishService(svc, requested) {
+		t.Fatal("expected applying the requested address to report a change")
+	}
+
+	svc.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "203.0.113.9"}}
+
+	got := programmedAddressesFromService(svc)
+	want := []gatewayv1alpha2.GatewayAddress{gatewayAddress(gatewayv1alpha2.IPAddressType, "203.0.113.9")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("programmedAddressesFromService() = %+v, want %+v (actual LB ingress, not the request)", got, want)
+	}
+}
+
+func TestGatewayAddressNotUsableCondition(t *testing.T) {
+	gw := &gatewayv1alpha2.Gateway{ObjectMeta: metav1.ObjectMeta{Generation: 2}}
+	condition := gatewayAddressNotUsableCondition(gw, "boom")
+
+	if condition.Status != metav1.ConditionFalse {
+		t.Errorf("Status = %v, want False", condition.Status)
+	}
+	if condition.Reason != "AddressNotUsable" {
+		t.Errorf("Reason = %q, want %q", condition.Reason, "AddressNotUsable")
+	}
+	if condition.ObservedGeneration != gw.Generation {
+		t.Errorf("ObservedGeneration = %d, want %d", condition.ObservedGeneration, gw.Generation)
+	}
+}
+
+func TestGatewayAddressUsableCondition(t *testing.T) {
+	gw := &gatewayv1alpha2.Gateway{ObjectMeta: metav1.ObjectMeta{Generation: 2}}
+	condition := gatewayAddressUsableCondition(gw)
+
+	if condition.Status != metav1.ConditionTrue {
+		t.Errorf("Status = %v, want True", condition.Status)
+	}
+	if condition.ObservedGeneration != gw.Generation {
+		t.Errorf("ObservedGeneration = %d, want %d", condition.ObservedGeneration, gw.Generation)
+	}
+}
+
+func TestIsPublishServiceAddressEventRelevant(t *testing.T) {
+	log := logr.Discard()
+
+	if !isPublishServiceAddressEventRelevant(log, event.CreateEvent{}) {
+		t.Error("create events should always be relevant")
+	}
+
+	unchanged := event.UpdateEvent{
+		ObjectOld: &corev1.Service{},
+		ObjectNew: &corev1.Service{},
+	}
+	if isPublishServiceAddressEventRelevant(log, unchanged) {
+		t.Error("an update with no address-related changes should not be relevant")
+	}
+
+	changed := event.UpdateEvent{
+		ObjectOld: &corev1.Service{},
+		ObjectNew: &corev1.Service{Spec: corev1.ServiceSpec{LoadBalancerIP: "10.0.0.1"}},
+	}
+	if !isPublishServiceAddressEventRelevant(log, changed) {
+		t.Error("an update that changes LoadBalancerIP should be relevant")
+	}
+}
@@ -0,0 +1,275 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/controllers/gateway/binding"
+)
+
+// Reconciler reconciles Gateway objects. Each reconcile runs the binding package's
+// engine against every HTTPRoute in the cluster and writes the resulting listener and
+// route status updates in a single pass, which is what keeps AttachedRoutes from lagging
+// behind the routes that are actually bound. TCPRoutes, TLSRoutes and UDPRoutes are not
+// listed, so they are never bound and never counted in AttachedRoutes.
+type Reconciler struct {
+	client.Client
+
+	Log logr.Logger
+
+	// PublishService is the namespaced name of the Service that fronts this controller's
+	// proxies. When set, Gateways that request static Spec.Addresses have those addresses
+	// programmed onto it, and changes to it re-trigger reconciliation of every Gateway that
+	// depends on it.
+	PublishService types.NamespacedName
+}
+
+// SetupWithManager wires the Reconciler into mgr.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	bldr := ctrl.NewControllerManagedBy(mgr).
+		For(&gatewayv1alpha2.Gateway{}).
+		Owns(&gatewayv1alpha2.HTTPRoute{})
+
+	if r.PublishService != (types.NamespacedName{}) {
+		bldr = bldr.Watches(
+			&corev1.Service{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueGatewaysForPublishService),
+			builder.WithPredicates(predicate.Funcs{
+				CreateFunc:  func(e event.CreateEvent) bool { return isPublishServiceAddressEventRelevant(r.Log, e) },
+				UpdateFunc:  func(e event.UpdateEvent) bool { return isPublishServiceAddressEventRelevant(r.Log, e) },
+				DeleteFunc:  func(e event.DeleteEvent) bool { return isPublishServiceAddressEventRelevant(r.Log, e) },
+				GenericFunc: func(e event.GenericEvent) bool { return isPublishServiceAddressEventRelevant(r.Log, e) },
+			}),
+		)
+	}
+
+	return bldr.Complete(r)
+}
+
+// Reconcile binds every candidate route against gateway's listeners, writes the
+// resulting listener/route status, and reconciles any requested static addresses.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("gateway", req.NamespacedName)
+
+	gateway := new(gatewayv1alpha2.Gateway)
+	if err := r.Get(ctx, req.NamespacedName, gateway); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	bindResult, err := r.bindRoutes(ctx, req.NamespacedName, gateway)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("binding routes for gateway %s: %w", req.NamespacedName, err)
+	}
+
+	if err := r.reconcileAddresses(ctx, gateway); err != nil {
+		return ctrl.Result{}, fmt.Errorf("reconciling addresses for gateway %s: %w", req.NamespacedName, err)
+	}
+
+	gateway.Status.Listeners = convertListenersToListenerStatuses(gateway, bindResult)
+	if err := r.Status().Update(ctx, gateway); err != nil {
+		return ctrl.Result{}, fmt.Errorf("updating listener status for gateway %s: %w", req.NamespacedName, err)
+	}
+
+	if err := r.applyRouteUpdates(ctx, bindResult); err != nil {
+		return ctrl.Result{}, fmt.Errorf("applying route status for gateway %s: %w", req.NamespacedName, err)
+	}
+
+	log.V(1).Info("reconciled gateway listeners and routes")
+	return ctrl.Result{}, nil
+}
+
+// bindRoutes gathers every candidate HTTPRoute and the namespace labels needed to
+// evaluate allowedRoutes selectors, then runs the binding engine against gateway's
+// listeners.
+func (r *Reconciler) bindRoutes(ctx context.Context, gatewayName types.NamespacedName, gateway *gatewayv1alpha2.Gateway) (binding.BindResult, error) {
+	httpRoutes := new(gatewayv1alpha2.HTTPRouteList)
+	if err := r.List(ctx, httpRoutes); err != nil {
+		return binding.BindResult{}, err
+	}
+
+	routes := make([]binding.Route, 0, len(httpRoutes.Items))
+	for i := range httpRoutes.Items {
+		routes = append(routes, httpRouteToBindingRoute(&httpRoutes.Items[i]))
+	}
+
+	namespaces := new(corev1.NamespaceList)
+	if err := r.List(ctx, namespaces); err != nil {
+		return binding.BindResult{}, err
+	}
+
+	namespaceLabels := make(map[string]map[string]string, len(namespaces.Items))
+	for _, ns := range namespaces.Items {
+		namespaceLabels[ns.Name] = ns.Labels
+	}
+
+	return binding.Bind(gatewayName, gateway.Spec.Listeners, routes, nil, namespaceLabels), nil
+}
+
+// httpRouteToBindingRoute adapts an HTTPRoute to the binding package's route-agnostic
+// Route shape.
+func httpRouteToBindingRoute(route *gatewayv1alpha2.HTTPRoute) binding.Route {
+	return binding.Route{
+		NamespacedName: types.NamespacedName{Namespace: route.Namespace, Name: route.Name},
+		Kind:           "HTTPRoute",
+		ParentRefs:     route.Spec.ParentRefs,
+		Hostnames:      route.Spec.Hostnames,
+	}
+}
+
+// reconcileAddresses validates any static addresses requested on gateway and, once
+// valid, programs them onto the publish Service, updating gateway's status to reflect
+// what the Service actually ended up with. It is a no-op when gateway requests no
+// addresses or PublishService isn't configured.
+func (r *Reconciler) reconcileAddresses(ctx context.Context, gateway *gatewayv1alpha2.Gateway) error {
+	if len(gateway.Spec.Addresses) == 0 || r.PublishService == (types.NamespacedName{}) {
+		return nil
+	}
+
+	conditions := NewConditionSet(gateway.Status.Conditions)
+	defer func() { gateway.Status.Conditions = conditions.Conditions() }()
+
+	if err := validateGatewayAddresses(gateway.Spec.Addresses); err != nil {
+		conditions.Set(gatewayAddressNotUsableCondition(gateway, err.Error()))
+		return nil
+	}
+
+	svc := new(corev1.Service)
+	if err := r.Get(ctx, r.PublishService, svc); err != nil {
+		return fmt.Errorf("getting publish service %s: %w", r.PublishService, err)
+	}
+
+	if applyStaticAddressesToPublishService(svc, gateway.Spec.Addresses) {
+		if err := r.Update(ctx, svc); err != nil {
+			return fmt.Errorf("updating publish service %s: %w", r.PublishService, err)
+		}
+	}
+
+	gateway.Status.Addresses = programmedAddressesFromService(svc)
+	conditions.Set(gatewayAddressUsableCondition(gateway))
+	return nil
+}
+
+// enqueueGatewaysForPublishService maps a change on the shared publish Service to a
+// reconcile request for every Gateway that requests static addresses, since any of them
+// may need those addresses reprogrammed or their Status.Addresses refreshed.
+func (r *Reconciler) enqueueGatewaysForPublishService(ctx context.Context, _ client.Object) []ctrl.Request {
+	gatewayList := new(gatewayv1alpha2.GatewayList)
+	if err := r.List(ctx, gatewayList); err != nil {
+		r.Log.Error(err, "listing gateways to requeue for publish service event")
+		return nil
+	}
+
+	var requests []ctrl.Request
+	for _, gw := range gatewayList.Items {
+		if len(gw.Spec.Addresses) == 0 {
+			continue
+		}
+		requests = append(requests, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: gw.Namespace, Name: gw.Name}})
+	}
+
+	return requests
+}
+
+// applyRouteUpdates writes the per-route parent status computed by the binding engine
+// back to each HTTPRoute.
+func (r *Reconciler) applyRouteUpdates(ctx context.Context, bindResult binding.BindResult) error {
+	for _, update := range bindResult.Routes {
+		if update.Kind != "HTTPRoute" {
+			continue
+		}
+
+		route := new(gatewayv1alpha2.HTTPRoute)
+		if err := r.Get(ctx, update.Route, route); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+
+		route.Status.Parents = mergeParentStatuses(route.Status.Parents, update.Parents)
+		if err := r.Status().Update(ctx, route); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mergeParentStatuses folds the binding engine's ParentStatus updates into a route's
+// existing RouteParentStatus list through a ConditionSet per parentRef, so
+// LastTransitionTime is preserved across reconciles that don't actually change
+// anything, matching the condition management convention used for Gateway and listener
+// status.
+func mergeParentStatuses(existing []gatewayv1alpha2.RouteParentStatus, updates []binding.ParentStatus) []gatewayv1alpha2.RouteParentStatus {
+	existingConditions := make(map[string][]metav1.Condition, len(existing))
+	for _, status := range existing {
+		existingConditions[parentRefKey(status.ParentRef)] = status.Conditions
+	}
+
+	updated := make(map[string]bool, len(updates))
+	result := make([]gatewayv1alpha2.RouteParentStatus, 0, len(existing)+len(updates))
+	for _, update := range updates {
+		updated[parentRefKey(update.ParentRef)] = true
+		conditions := NewConditionSet(existingConditions[parentRefKey(update.ParentRef)])
+		for _, condition := range update.Conditions {
+			conditions.Set(condition)
+		}
+
+		result = append(result, gatewayv1alpha2.RouteParentStatus{
+			ParentRef:      update.ParentRef,
+			ControllerName: ControllerName,
+			Conditions:     conditions.Conditions(),
+		})
+	}
+
+	for _, status := range existing {
+		if !updated[parentRefKey(status.ParentRef)] {
+			result = append(result, status)
+		}
+	}
+
+	return result
+}
+
+// parentRefKey builds a comparable key for a ParentReference. ParentReference itself
+// isn't safe to use as a map key for this purpose: its optional fields are pointers, so
+// two references with identical values but freshly-unmarshaled pointers would never
+// compare equal.
+func parentRefKey(ref gatewayv1alpha2.ParentReference) string {
+	var group, kind, namespace, sectionName string
+	var port gatewayv1alpha2.PortNumber
+
+	if ref.Group != nil {
+		group = string(*ref.Group)
+	}
+	if ref.Kind != nil {
+		kind = string(*ref.Kind)
+	}
+	if ref.Namespace != nil {
+		namespace = string(*ref.Namespace)
+	}
+	if ref.SectionName != nil {
+		sectionName = string(*ref.SectionName)
+	}
+	if ref.Port != nil {
+		port = *ref.Port
+	}
+
+	return fmt.Sprintf("%s/%s/%s/%s/%s/%d", group, kind, namespace, ref.Name, sectionName, port)
+}
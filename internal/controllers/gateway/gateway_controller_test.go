@@ -0,0 +1,72 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+func newAddressesTestScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("adding corev1 to scheme: %v", err)
+	}
+	if err := gatewayv1alpha2.AddToScheme(scheme); err != nil {
+		t.Fatalf("adding gatewayv1alpha2 to scheme: %v", err)
+	}
+	return scheme
+}
+
+// TestReconcileAddressesClearsStaleProgrammedFalse covers the case where a Gateway
+// previously had Programmed=False/AddressNotUsable recorded (e.g. a typo'd address) and
+// the request is now valid: reconcileAddresses must clear the stale condition rather than
+// leaving it in place forever.
+func TestReconcileAddressesClearsStaleProgrammedFalse(t *testing.T) {
+	publishService := types.NamespacedName{Namespace: "kong", Name: "proxy"}
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: publishService.Namespace, Name: publishService.Name},
+	}
+
+	ipType := gatewayv1alpha2.IPAddressType
+	gw := &gatewayv1alpha2.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-gateway", Namespace: "default", Generation: 2},
+		Spec: gatewayv1alpha2.GatewaySpec{
+			Addresses: []gatewayv1alpha2.GatewayAddress{{Type: &ipType, Value: "10.0.0.1"}},
+		},
+		Status: gatewayv1alpha2.GatewayStatus{
+			Conditions: []metav1.Condition{{
+				Type:               string(gatewayv1alpha2.GatewayConditionProgrammed),
+				Status:             metav1.ConditionFalse,
+				LastTransitionTime: metav1.Now(),
+				Reason:             "AddressNotUsable",
+				Message:            "previously invalid",
+			}},
+		},
+	}
+
+	r := &Reconciler{
+		Client:         fake.NewClientBuilder().WithScheme(newAddressesTestScheme(t)).WithObjects(svc).Build(),
+		PublishService: publishService,
+	}
+
+	if err := r.reconcileAddresses(context.Background(), gw); err != nil {
+		t.Fatalf("reconcileAddresses() error = %v", err)
+	}
+
+	programmed := meta.FindStatusCondition(gw.Status.Conditions, string(gatewayv1alpha2.GatewayConditionProgrammed))
+	if programmed == nil {
+		t.Fatal("expected a Programmed condition after a successful reconcile")
+	}
+	if programmed.Status != metav1.ConditionTrue {
+		t.Errorf("Programmed condition Status = %v, want True (stale False should have been cleared)", programmed.Status)
+	}
+}
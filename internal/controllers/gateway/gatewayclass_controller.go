@@ -0,0 +1,138 @@
+package gateway
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// GatewayClassReconciler reconciles GatewayClass objects that reference this
+// controller's ControllerName. It records whether each such class is actually usable
+// (Accepted=True/False with a Reason), and propagates that decision to every Gateway
+// bound to it, so that a GatewayClass we otherwise ignore still leaves the user a
+// signal for why.
+type GatewayClassReconciler struct {
+	client.Client
+
+	Log logr.Logger
+}
+
+// SetupWithManager wires the GatewayClassReconciler into mgr. It also watches Gateway
+// objects, mapped back to the GatewayClass they reference, so that a Gateway created (or
+// whose GatewayClassName changes) after its class was already reconciled gets the
+// Accepted condition promptly instead of waiting for some unrelated event to touch the
+// class again.
+func (r *GatewayClassReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&gatewayv1alpha2.GatewayClass{}).
+		Watches(
+			&gatewayv1alpha2.Gateway{},
+			handler.EnqueueRequestsFromMapFunc(mapGatewayToGatewayClassRequest),
+		).
+		Complete(r)
+}
+
+// mapGatewayToGatewayClassRequest requeues the GatewayClass referenced by a Gateway
+// whenever that Gateway changes.
+func mapGatewayToGatewayClassRequest(_ context.Context, obj client.Object) []ctrl.Request {
+	gw, ok := obj.(*gatewayv1alpha2.Gateway)
+	if !ok || gw.Spec.GatewayClassName == "" {
+		return nil
+	}
+
+	return []ctrl.Request{{NamespacedName: types.NamespacedName{Name: string(gw.Spec.GatewayClassName)}}}
+}
+
+// Reconcile determines whether the GatewayClass named by req is accepted by this
+// controller and writes that decision, along with its knock-on effect on any Gateway
+// bound to the class, to the cluster.
+func (r *GatewayClassReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("gatewayclass", req.NamespacedName)
+
+	gatewayClass := new(gatewayv1alpha2.GatewayClass)
+	if err := r.Get(ctx, req.NamespacedName, gatewayClass); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if gatewayClass.Spec.ControllerName != ControllerName {
+		return ctrl.Result{}, nil
+	}
+
+	accepted, err := r.reconcileClassStatus(ctx, gatewayClass)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.propagateToGateways(ctx, gatewayClass, accepted); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	log.V(1).Info("reconciled gatewayclass", "accepted", accepted)
+	return ctrl.Result{}, nil
+}
+
+// reconcileClassStatus determines whether gatewayClass is accepted (taking into account
+// any other GatewayClass that already claims our ControllerName) and writes the
+// resulting Accepted condition onto it.
+func (r *GatewayClassReconciler) reconcileClassStatus(ctx context.Context, gatewayClass *gatewayv1alpha2.GatewayClass) (bool, error) {
+	classList := new(gatewayv1alpha2.GatewayClassList)
+	if err := r.List(ctx, classList); err != nil {
+		return false, err
+	}
+
+	var ours []gatewayv1alpha2.GatewayClass
+	for _, candidate := range classList.Items {
+		if candidate.Spec.ControllerName == ControllerName {
+			ours = append(ours, candidate)
+		}
+	}
+
+	var conflicting *gatewayv1alpha2.GatewayClass
+	if oldest := oldestGatewayClassForController(ours); oldest != nil && oldest.Name != gatewayClass.Name {
+		conflicting = oldest
+	}
+
+	accepted, reason, message := determineGatewayClassAccepted(gatewayClass, conflicting)
+
+	conditions := NewConditionSet(gatewayClass.Status.Conditions)
+	conditions.Set(gatewayClassAcceptedCondition(gatewayClass, accepted, reason, message))
+	gatewayClass.Status.Conditions = conditions.Conditions()
+
+	return accepted, r.Status().Update(ctx, gatewayClass)
+}
+
+// propagateToGateways writes the Accepted condition that follows from classAccepted
+// onto every Gateway that references gatewayClass, so a Gateway bound to an ignored
+// class still reports why nothing was provisioned for it.
+func (r *GatewayClassReconciler) propagateToGateways(ctx context.Context, gatewayClass *gatewayv1alpha2.GatewayClass, classAccepted bool) error {
+	gatewayList := new(gatewayv1alpha2.GatewayList)
+	if err := r.List(ctx, gatewayList); err != nil {
+		return err
+	}
+
+	for i := range gatewayList.Items {
+		gw := &gatewayList.Items[i]
+		if string(gw.Spec.GatewayClassName) != gatewayClass.Name {
+			continue
+		}
+
+		conditions := NewConditionSet(gw.Status.Conditions)
+		conditions.Set(gatewayAcceptedConditionForClass(gw, classAccepted))
+		gw.Status.Conditions = conditions.Conditions()
+
+		if err := r.Status().Update(ctx, gw); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
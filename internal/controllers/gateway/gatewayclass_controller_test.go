@@ -0,0 +1,32 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+func TestMapGatewayToGatewayClassRequest(t *testing.T) {
+	gw := &gatewayv1alpha2.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-gateway", Namespace: "default"},
+		Spec:       gatewayv1alpha2.GatewaySpec{GatewayClassName: "kong"},
+	}
+
+	requests := mapGatewayToGatewayClassRequest(context.Background(), gw)
+	if len(requests) != 1 {
+		t.Fatalf("len(requests) = %d, want 1", len(requests))
+	}
+	if requests[0].Name != "kong" {
+		t.Errorf("requests[0].Name = %q, want %q", requests[0].Name, "kong")
+	}
+	if requests[0].Namespace != "" {
+		t.Errorf("requests[0].Namespace = %q, want empty (GatewayClass is cluster-scoped)", requests[0].Namespace)
+	}
+
+	noClass := &gatewayv1alpha2.Gateway{ObjectMeta: metav1.ObjectMeta{Name: "my-gateway", Namespace: "default"}}
+	if got := mapGatewayToGatewayClassRequest(context.Background(), noClass); got != nil {
+		t.Errorf("mapGatewayToGatewayClassRequest() = %v, want nil for a Gateway with no GatewayClassName", got)
+	}
+}
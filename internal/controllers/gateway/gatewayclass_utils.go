@@ -0,0 +1,132 @@
+package gateway
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// -----------------------------------------------------------------------------
+// GatewayClass Utilities
+// -----------------------------------------------------------------------------
+
+// gatewayClassAcceptedReason indicates why a GatewayClass referencing this controller is,
+// or is not, usable.
+type gatewayClassAcceptedReason string
+
+const (
+	// gatewayClassReasonAccepted indicates the GatewayClass was accepted.
+	gatewayClassReasonAccepted gatewayClassAcceptedReason = gatewayClassAcceptedReason(gatewayv1alpha2.GatewayClassReasonAccepted)
+
+	// gatewayClassReasonInvalidParameters indicates the GatewayClass' ParametersRef could
+	// not be resolved or is not supported by this controller.
+	gatewayClassReasonInvalidParameters gatewayClassAcceptedReason = gatewayClassAcceptedReason(gatewayv1alpha2.GatewayClassReasonInvalidParameters)
+
+	// gatewayClassReasonWaiting indicates a GatewayClass is otherwise valid but is not yet
+	// accepted, e.g. because a different GatewayClass for the same ControllerName already
+	// holds that position.
+	gatewayClassReasonWaiting gatewayClassAcceptedReason = gatewayClassAcceptedReason(gatewayv1alpha2.GatewayClassReasonWaiting)
+)
+
+// supportedParametersRefGroupKind is the only ParametersRef Group/Kind combination this
+// controller currently knows how to resolve.
+var supportedParametersRefGroupKind = struct {
+	Group gatewayv1alpha2.Group
+	Kind  gatewayv1alpha2.Kind
+}{
+	Group: "",
+	Kind:  "ConfigMap",
+}
+
+// determineGatewayClassAccepted evaluates whether the given GatewayClass, which has
+// already been confirmed to reference this controller's ControllerName, is actually
+// usable. It returns false along with a reason and a human-readable message when the
+// class should be ignored, so that callers can still surface that decision in status
+// instead of silently dropping the object.
+func determineGatewayClassAccepted(gatewayClass *gatewayv1alpha2.GatewayClass, conflictingClass *gatewayv1alpha2.GatewayClass) (bool, gatewayClassAcceptedReason, string) {
+	if ref := gatewayClass.Spec.ParametersRef; ref != nil {
+		if ref.Group != supportedParametersRefGroupKind.Group || ref.Kind != supportedParametersRefGroupKind.Kind {
+			return false, gatewayClassReasonInvalidParameters, fmt.Sprintf(
+				"parametersRef group/kind %s/%s is not supported, expected %s/%s",
+				ref.Group, ref.Kind, supportedParametersRefGroupKind.Group, supportedParametersRefGroupKind.Kind,
+			)
+		}
+	}
+
+	if conflictingClass != nil {
+		return false, gatewayClassReasonWaiting, fmt.Sprintf(
+			"GatewayClass %s already accepts ControllerName %s", conflictingClass.Name, gatewayClass.Spec.ControllerName,
+		)
+	}
+
+	return true, gatewayClassReasonAccepted, "the gatewayclass has been accepted by the controller"
+}
+
+// oldestGatewayClassForController returns, out of the given list of GatewayClasses that
+// all reference the same ControllerName, the one that should be treated as accepted
+// (oldest by creation timestamp, name as a tiebreaker), matching the Gateway API
+// requirement that only one GatewayClass per ControllerName is accepted at a time. It
+// returns nil if classes is empty.
+func oldestGatewayClassForController(classes []gatewayv1alpha2.GatewayClass) *gatewayv1alpha2.GatewayClass {
+	var oldest *gatewayv1alpha2.GatewayClass
+	for i := range classes {
+		candidate := &classes[i]
+		if oldest == nil {
+			oldest = candidate
+			continue
+		}
+		if candidate.CreationTimestamp.Before(&oldest.CreationTimestamp) {
+			oldest = candidate
+			continue
+		}
+		if candidate.CreationTimestamp.Equal(&oldest.CreationTimestamp) && candidate.Name < oldest.Name {
+			oldest = candidate
+		}
+	}
+	return oldest
+}
+
+// gatewayClassAcceptedCondition builds the Accepted status condition for a GatewayClass
+// given the outcome of determineGatewayClassAccepted.
+func gatewayClassAcceptedCondition(gatewayClass *gatewayv1alpha2.GatewayClass, accepted bool, reason gatewayClassAcceptedReason, message string) metav1.Condition {
+	status := metav1.ConditionFalse
+	if accepted {
+		status = metav1.ConditionTrue
+	}
+
+	return metav1.Condition{
+		Type:               string(gatewayv1alpha2.GatewayClassConditionStatusAccepted),
+		Status:             status,
+		ObservedGeneration: gatewayClass.Generation,
+		LastTransitionTime: metav1.Now(),
+		Reason:             string(reason),
+		Message:            message,
+	}
+}
+
+// gatewayAcceptedConditionForClass builds the Accepted condition that a Gateway bound to
+// gatewayClassAccepted should carry: when the GatewayClass itself was not accepted, the
+// Gateway can't be either, and we surface the reason on the Gateway so that a user
+// looking only at their Gateway's status still understands why nothing was provisioned.
+func gatewayAcceptedConditionForClass(gateway *gatewayv1alpha2.Gateway, gatewayClassAccepted bool) metav1.Condition {
+	if !gatewayClassAccepted {
+		return metav1.Condition{
+			Type:               string(gatewayv1alpha2.GatewayConditionAccepted),
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: gateway.Generation,
+			LastTransitionTime: metav1.Now(),
+			Reason:             "NoSuchGatewayClass",
+			Message:            fmt.Sprintf("GatewayClass %s is not accepted by the controller", gateway.Spec.GatewayClassName),
+		}
+	}
+
+	return metav1.Condition{
+		Type:               string(gatewayv1alpha2.GatewayConditionAccepted),
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: gateway.Generation,
+		LastTransitionTime: metav1.Now(),
+		Reason:             string(gatewayv1alpha2.GatewayReasonAccepted),
+		Message:            "the gateway has been accepted by the controller",
+	}
+}
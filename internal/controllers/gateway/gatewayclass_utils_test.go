@@ -0,0 +1,110 @@
+package gateway
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+func TestDetermineGatewayClassAccepted(t *testing.T) {
+	unsupportedGroup := gatewayv1alpha2.Group("example.com")
+	unsupportedKind := gatewayv1alpha2.Kind("Secret")
+
+	for _, tt := range []struct {
+		name             string
+		gatewayClass     *gatewayv1alpha2.GatewayClass
+		conflictingClass *gatewayv1alpha2.GatewayClass
+		wantAccepted     bool
+		wantReason       gatewayClassAcceptedReason
+	}{
+		{
+			name:         "plain class with no parametersRef is accepted",
+			gatewayClass: &gatewayv1alpha2.GatewayClass{ObjectMeta: metav1.ObjectMeta{Name: "kong"}},
+			wantAccepted: true,
+			wantReason:   gatewayClassReasonAccepted,
+		},
+		{
+			name: "unsupported parametersRef group/kind is ignored but still gets a status",
+			gatewayClass: &gatewayv1alpha2.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "kong"},
+				Spec: gatewayv1alpha2.GatewayClassSpec{
+					ParametersRef: &gatewayv1alpha2.ParametersReference{
+						Group: unsupportedGroup,
+						Kind:  unsupportedKind,
+						Name:  "irrelevant",
+					},
+				},
+			},
+			wantAccepted: false,
+			wantReason:   gatewayClassReasonInvalidParameters,
+		},
+		{
+			name:             "a referenced but conflicting duplicate class is marked waiting",
+			gatewayClass:     &gatewayv1alpha2.GatewayClass{ObjectMeta: metav1.ObjectMeta{Name: "kong-2"}},
+			conflictingClass: &gatewayv1alpha2.GatewayClass{ObjectMeta: metav1.ObjectMeta{Name: "kong-1"}},
+			wantAccepted:     false,
+			wantReason:       gatewayClassReasonWaiting,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			accepted, reason, message := determineGatewayClassAccepted(tt.gatewayClass, tt.conflictingClass)
+			if accepted != tt.wantAccepted {
+				t.Errorf("accepted = %v, want %v", accepted, tt.wantAccepted)
+			}
+			if reason != tt.wantReason {
+				t.Errorf("reason = %v, want %v", reason, tt.wantReason)
+			}
+			if message == "" {
+				t.Error("expected a non-empty message explaining the decision")
+			}
+		})
+	}
+}
+
+func TestOldestGatewayClassForController(t *testing.T) {
+	now := metav1.NewTime(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	later := metav1.NewTime(now.Add(time.Hour))
+
+	classes := []gatewayv1alpha2.GatewayClass{
+		{ObjectMeta: metav1.ObjectMeta{Name: "kong-b", CreationTimestamp: now}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "kong-a", CreationTimestamp: now}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "kong-c", CreationTimestamp: later}},
+	}
+
+	oldest := oldestGatewayClassForController(classes)
+	if oldest == nil {
+		t.Fatal("expected a non-nil result")
+	}
+	if oldest.Name != "kong-a" {
+		t.Errorf("oldest.Name = %q, want %q (same timestamp ties broken by name)", oldest.Name, "kong-a")
+	}
+
+	if got := oldestGatewayClassForController(nil); got != nil {
+		t.Errorf("oldestGatewayClassForController(nil) = %v, want nil", got)
+	}
+}
+
+func TestGatewayAcceptedConditionForClass(t *testing.T) {
+	gw := &gatewayv1alpha2.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-gateway", Generation: 3},
+		Spec:       gatewayv1alpha2.GatewaySpec{GatewayClassName: "kong"},
+	}
+
+	rejected := gatewayAcceptedConditionForClass(gw, false)
+	if rejected.Status != metav1.ConditionFalse {
+		t.Errorf("Status = %v, want False when the class is not accepted", rejected.Status)
+	}
+	if rejected.Reason != "NoSuchGatewayClass" {
+		t.Errorf("Reason = %q, want %q", rejected.Reason, "NoSuchGatewayClass")
+	}
+	if rejected.ObservedGeneration != gw.Generation {
+		t.Errorf("ObservedGeneration = %d, want %d", rejected.ObservedGeneration, gw.Generation)
+	}
+
+	accepted := gatewayAcceptedConditionForClass(gw, true)
+	if accepted.Status != metav1.ConditionTrue {
+		t.Errorf("Status = %v, want True when the class is accepted", accepted.Status)
+	}
+}
@@ -0,0 +1,20 @@
+package provisioner
+
+import (
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// ProvisionerModeAnnotation, when set to ProvisionerModeDynamic on a Gateway, opts that
+// Gateway into getting its own dedicated proxy Deployment/Service pair instead of
+// sharing the cluster's single publish Service.
+const ProvisionerModeAnnotation = "konghq.com/provisioner-mode"
+
+// ProvisionerModeDynamic is the only recognized value for ProvisionerModeAnnotation
+// today.
+const ProvisionerModeDynamic = "dynamic"
+
+// isDynamicProvisionerGateway reports whether gateway has opted into provisioner mode
+// via ProvisionerModeAnnotation.
+func isDynamicProvisionerGateway(gateway *gatewayv1alpha2.Gateway) bool {
+	return gateway.Annotations[ProvisionerModeAnnotation] == ProvisionerModeDynamic
+}
@@ -0,0 +1,44 @@
+package provisioner
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// programmedConditionForDeployment builds the Programmed condition to report on a
+// provisioner-mode Gateway, reflecting whether its child Deployment has finished rolling
+// out and its child Service has been assigned an address.
+func programmedConditionForDeployment(gateway *gatewayv1alpha2.Gateway, deployment *appsv1.Deployment, svc *corev1.Service) metav1.Condition {
+	if deployment.Status.ReadyReplicas < *deployment.Spec.Replicas {
+		return metav1.Condition{
+			Type:               string(gatewayv1alpha2.GatewayConditionProgrammed),
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: gateway.Generation,
+			LastTransitionTime: metav1.Now(),
+			Reason:             "DeploymentNotReady",
+			Message:            "waiting for the provisioned proxy deployment to become ready",
+		}
+	}
+
+	if len(svc.Status.LoadBalancer.Ingress) == 0 {
+		return metav1.Condition{
+			Type:               string(gatewayv1alpha2.GatewayConditionProgrammed),
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: gateway.Generation,
+			LastTransitionTime: metav1.Now(),
+			Reason:             "AddressNotAssigned",
+			Message:            "waiting for the provisioned service to be assigned an address",
+		}
+	}
+
+	return metav1.Condition{
+		Type:               string(gatewayv1alpha2.GatewayConditionProgrammed),
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: gateway.Generation,
+		LastTransitionTime: metav1.Now(),
+		Reason:             string(gatewayv1alpha2.GatewayReasonProgrammed),
+		Message:            "the provisioned proxy deployment and service are ready",
+	}
+}
@@ -0,0 +1,18 @@
+package provisioner
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// DeploymentConfig configures the proxy Deployment that the provisioner creates for each
+// dynamically-provisioned Gateway.
+type DeploymentConfig struct {
+	// Image is the Kong proxy container image to run.
+	Image string
+
+	// Resources are the resource requirements applied to the proxy container.
+	Resources corev1.ResourceRequirements
+
+	// Replicas is the number of proxy pods to run for each provisioned Gateway.
+	Replicas int32
+}
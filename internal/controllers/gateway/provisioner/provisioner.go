@@ -0,0 +1,206 @@
+// Package provisioner implements an optional Gateway sub-controller that gives select
+// Gateways their own dedicated Kong proxy Deployment/Service pair instead of sharing the
+// cluster's single publish Service. It is opt-in per Gateway via
+// ProvisionerModeAnnotation, so it composes with the existing shared-publish-service
+// flow rather than replacing it.
+package provisioner
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/controllers/gateway"
+)
+
+// Reconciler provisions and garbage-collects the per-Gateway Deployment/Service pair for
+// Gateways running in provisioner mode.
+type Reconciler struct {
+	client.Client
+
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+
+	// DeploymentConfig configures the Deployment created for each provisioned Gateway.
+	DeploymentConfig DeploymentConfig
+}
+
+// SetupWithManager wires the Reconciler into mgr, watching Gateways and their owned
+// Deployments/Services.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&gatewayv1alpha2.Gateway{}).
+		Owns(&appsv1.Deployment{}).
+		Owns(&corev1.Service{}).
+		Complete(r)
+}
+
+// Reconcile ensures that the Deployment and Service for a provisioner-mode Gateway exist
+// and match the desired spec, and reflects their rollout into the Gateway's conditions.
+// Gateways not in provisioner mode, and Gateways that have been deleted, are ignored;
+// owned child resources are garbage-collected by the API server via ownerReferences.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("gateway", req.NamespacedName)
+
+	gw := new(gatewayv1alpha2.Gateway)
+	if err := r.Get(ctx, req.NamespacedName, gw); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if !isDynamicProvisionerGateway(gw) {
+		return ctrl.Result{}, nil
+	}
+
+	owned, err := r.gatewayClassOwnedByThisController(ctx, gw)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("checking gatewayclass for gateway %s: %w", req.NamespacedName, err)
+	}
+	if !owned {
+		return ctrl.Result{}, nil
+	}
+
+	deployment, err := r.ensureDeployment(ctx, gw)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("ensuring deployment for gateway %s: %w", req.NamespacedName, err)
+	}
+
+	svc, err := r.ensureService(ctx, gw)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("ensuring service for gateway %s: %w", req.NamespacedName, err)
+	}
+
+	condition := programmedConditionForDeployment(gw, deployment, svc)
+	if gatewayConditionChanged(gw, condition) {
+		meta.SetStatusCondition(&gw.Status.Conditions, condition)
+		if err := r.Status().Update(ctx, gw); err != nil {
+			return ctrl.Result{}, fmt.Errorf("updating status for gateway %s: %w", req.NamespacedName, err)
+		}
+	}
+
+	log.V(1).Info("reconciled provisioner-mode gateway")
+	return ctrl.Result{}, nil
+}
+
+// gatewayClassOwnedByThisController reports whether gw's GatewayClass both names this
+// controller's ControllerName and has actually been accepted, so that a Gateway carrying
+// ProvisionerModeAnnotation doesn't get provisioned for just any controller's class.
+func (r *Reconciler) gatewayClassOwnedByThisController(ctx context.Context, gw *gatewayv1alpha2.Gateway) (bool, error) {
+	gatewayClass := new(gatewayv1alpha2.GatewayClass)
+	if err := r.Get(ctx, types.NamespacedName{Name: string(gw.Spec.GatewayClassName)}, gatewayClass); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if gatewayClass.Spec.ControllerName != gateway.ControllerName {
+		return false, nil
+	}
+
+	return meta.IsStatusConditionTrue(gatewayClass.Status.Conditions, string(gatewayv1alpha2.GatewayClassConditionStatusAccepted)), nil
+}
+
+// ensureDeployment creates the Gateway's child Deployment if it does not already exist,
+// updating it in place when the existing Deployment has drifted from the desired spec, and
+// returns the Deployment in its current (post-reconcile) state.
+func (r *Reconciler) ensureDeployment(ctx context.Context, gw *gatewayv1alpha2.Gateway) (*appsv1.Deployment, error) {
+	desired := newDeploymentForGateway(gw, r.DeploymentConfig)
+	if err := controllerutil.SetControllerReference(gw, desired, r.Scheme); err != nil {
+		return nil, err
+	}
+
+	existing := new(appsv1.Deployment)
+	err := r.Get(ctx, types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}, existing)
+	switch {
+	case apierrors.IsNotFound(err):
+		if err := r.Create(ctx, desired); err != nil {
+			return nil, err
+		}
+		return desired, nil
+	case err != nil:
+		return nil, err
+	}
+
+	if !deploymentNeedsUpdate(existing, desired) {
+		return existing, nil
+	}
+
+	existing.Spec.Replicas = desired.Spec.Replicas
+	existing.Spec.Template = desired.Spec.Template
+	if err := r.Update(ctx, existing); err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+// deploymentNeedsUpdate reports whether existing has drifted from desired in a way that
+// ensureDeployment is responsible for correcting, so that changes to DeploymentConfig
+// (image, resources, replica count) propagate to Gateways that were already provisioned.
+func deploymentNeedsUpdate(existing, desired *appsv1.Deployment) bool {
+	return !reflect.DeepEqual(existing.Spec.Replicas, desired.Spec.Replicas) ||
+		!reflect.DeepEqual(existing.Spec.Template, desired.Spec.Template)
+}
+
+// ensureService creates the Gateway's child Service if it does not already exist, updating
+// it in place when the existing Service has drifted from the desired spec, and returns the
+// Service in its current (post-reconcile) state.
+func (r *Reconciler) ensureService(ctx context.Context, gw *gatewayv1alpha2.Gateway) (*corev1.Service, error) {
+	desired := newServiceForGateway(gw)
+	if err := controllerutil.SetControllerReference(gw, desired, r.Scheme); err != nil {
+		return nil, err
+	}
+
+	existing := new(corev1.Service)
+	err := r.Get(ctx, types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}, existing)
+	switch {
+	case apierrors.IsNotFound(err):
+		if err := r.Create(ctx, desired); err != nil {
+			return nil, err
+		}
+		return desired, nil
+	case err != nil:
+		return nil, err
+	}
+
+	if !serviceNeedsUpdate(existing, desired) {
+		return existing, nil
+	}
+
+	existing.Spec.Selector = desired.Spec.Selector
+	existing.Spec.Ports = desired.Spec.Ports
+	if err := r.Update(ctx, existing); err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+// serviceNeedsUpdate reports whether existing has drifted from desired in a way that
+// ensureService is responsible for correcting; Type and ClusterIP are deliberately left
+// out of the comparison since both are immutable once assigned by the API server.
+func serviceNeedsUpdate(existing, desired *corev1.Service) bool {
+	return !reflect.DeepEqual(existing.Spec.Selector, desired.Spec.Selector) ||
+		!reflect.DeepEqual(existing.Spec.Ports, desired.Spec.Ports)
+}
+
+// gatewayConditionChanged reports whether setting condition on gw would actually change
+// its current status, so callers can skip a no-op status update.
+func gatewayConditionChanged(gw *gatewayv1alpha2.Gateway, condition metav1.Condition) bool {
+	existing := meta.FindStatusCondition(gw.Status.Conditions, condition.Type)
+	return existing == nil || existing.Status != condition.Status || existing.Reason != condition.Reason || existing.ObservedGeneration != condition.ObservedGeneration
+}
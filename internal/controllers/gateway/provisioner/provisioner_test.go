@@ -0,0 +1,141 @@
+package provisioner
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/controllers/gateway"
+)
+
+func newTestScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("adding corev1 to scheme: %v", err)
+	}
+	if err := appsv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("adding appsv1 to scheme: %v", err)
+	}
+	if err := gatewayv1alpha2.AddToScheme(scheme); err != nil {
+		t.Fatalf("adding gatewayv1alpha2 to scheme: %v", err)
+	}
+	return scheme
+}
+
+func TestDeploymentNeedsUpdate(t *testing.T) {
+	gw := &gatewayv1alpha2.Gateway{ObjectMeta: metav1.ObjectMeta{Name: "my-gateway", Namespace: "default"}}
+	cfg := DeploymentConfig{Image: "kong:3.0", Replicas: 2}
+
+	desired := newDeploymentForGateway(gw, cfg)
+	existing := newDeploymentForGateway(gw, cfg)
+	if deploymentNeedsUpdate(existing, desired) {
+		t.Error("expected no update when the existing deployment already matches desired")
+	}
+
+	scaled := newDeploymentForGateway(gw, DeploymentConfig{Image: "kong:3.0", Replicas: 4})
+	if !deploymentNeedsUpdate(existing, scaled) {
+		t.Error("expected an update when the desired replica count changed")
+	}
+
+	reimaged := newDeploymentForGateway(gw, DeploymentConfig{Image: "kong:3.1", Replicas: 2})
+	if !deploymentNeedsUpdate(existing, reimaged) {
+		t.Error("expected an update when the desired image changed")
+	}
+}
+
+func TestServiceNeedsUpdate(t *testing.T) {
+	gw := &gatewayv1alpha2.Gateway{ObjectMeta: metav1.ObjectMeta{Name: "my-gateway", Namespace: "default"}}
+
+	desired := newServiceForGateway(gw)
+	existing := newServiceForGateway(gw)
+	if serviceNeedsUpdate(existing, desired) {
+		t.Error("expected no update when the existing service already matches desired")
+	}
+
+	existing.Spec.Ports = []corev1.ServicePort{{Name: "proxy", Port: 8080}}
+	if !serviceNeedsUpdate(existing, desired) {
+		t.Error("expected an update when the existing service's ports have drifted")
+	}
+}
+
+func TestGatewayClassOwnedByThisController(t *testing.T) {
+	accepted := metav1.Condition{
+		Type:   string(gatewayv1alpha2.GatewayClassConditionStatusAccepted),
+		Status: metav1.ConditionTrue,
+		Reason: string(gatewayv1alpha2.GatewayClassReasonAccepted),
+	}
+
+	for _, tt := range []struct {
+		name         string
+		gatewayClass *gatewayv1alpha2.GatewayClass
+		wantOwned    bool
+	}{
+		{
+			name: "owned and accepted",
+			gatewayClass: &gatewayv1alpha2.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "kong"},
+				Spec:       gatewayv1alpha2.GatewayClassSpec{ControllerName: gateway.ControllerName},
+				Status:     gatewayv1alpha2.GatewayClassStatus{Conditions: []metav1.Condition{accepted}},
+			},
+			wantOwned: true,
+		},
+		{
+			name: "owned but not accepted",
+			gatewayClass: &gatewayv1alpha2.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "kong"},
+				Spec:       gatewayv1alpha2.GatewayClassSpec{ControllerName: gateway.ControllerName},
+			},
+			wantOwned: false,
+		},
+		{
+			name: "accepted but owned by a different controller",
+			gatewayClass: &gatewayv1alpha2.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "kong"},
+				Spec:       gatewayv1alpha2.GatewayClassSpec{ControllerName: "example.com/other-controller"},
+				Status:     gatewayv1alpha2.GatewayClassStatus{Conditions: []metav1.Condition{accepted}},
+			},
+			wantOwned: false,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			gw := &gatewayv1alpha2.Gateway{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-gateway", Namespace: "default"},
+				Spec:       gatewayv1alpha2.GatewaySpec{GatewayClassName: gatewayv1alpha2.ObjectName(tt.gatewayClass.Name)},
+			}
+
+			r := &Reconciler{Client: fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(tt.gatewayClass).Build()}
+
+			owned, err := r.gatewayClassOwnedByThisController(context.Background(), gw)
+			if err != nil {
+				t.Fatalf("gatewayClassOwnedByThisController() error = %v", err)
+			}
+			if owned != tt.wantOwned {
+				t.Errorf("gatewayClassOwnedByThisController() = %v, want %v", owned, tt.wantOwned)
+			}
+		})
+	}
+
+	t.Run("gatewayclass not found", func(t *testing.T) {
+		gw := &gatewayv1alpha2.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-gateway", Namespace: "default"},
+			Spec:       gatewayv1alpha2.GatewaySpec{GatewayClassName: "does-not-exist"},
+		}
+		r := &Reconciler{Client: fake.NewClientBuilder().WithScheme(newTestScheme(t)).Build()}
+
+		owned, err := r.gatewayClassOwnedByThisController(context.Background(), gw)
+		if err != nil {
+			t.Fatalf("gatewayClassOwnedByThisController() error = %v", err)
+		}
+		if owned {
+			t.Error("expected owned = false for a GatewayClass that does not exist")
+		}
+	})
+}
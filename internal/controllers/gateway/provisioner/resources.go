@@ -0,0 +1,93 @@
+package provisioner
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// childName is the name given to the Deployment and Service provisioned for a Gateway;
+// both are namespaced to the Gateway's own namespace, so a shared name is unambiguous.
+func childName(gateway *gatewayv1alpha2.Gateway) string {
+	return "gateway-" + gateway.Name
+}
+
+// labelsForGateway returns the labels applied to every child resource provisioned for
+// gateway, used both to set labels on creation and as the child Deployment's pod
+// selector.
+func labelsForGateway(gateway *gatewayv1alpha2.Gateway) map[string]string {
+	return map[string]string{
+		"konghq.com/gateway-namespace": gateway.Namespace,
+		"konghq.com/gateway-name":      gateway.Name,
+	}
+}
+
+// newDeploymentForGateway builds the (not-yet-created) Deployment that runs the proxy
+// for gateway, according to cfg.
+func newDeploymentForGateway(gateway *gatewayv1alpha2.Gateway, cfg DeploymentConfig) *appsv1.Deployment {
+	labels := labelsForGateway(gateway)
+	replicas := cfg.Replicas
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      childName(gateway),
+			Namespace: gateway.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:      "proxy",
+							Image:     cfg.Image,
+							Resources: cfg.Resources,
+							Args:      []string{publishServiceArg(gateway)},
+							Ports: []corev1.ContainerPort{
+								{Name: "proxy", ContainerPort: 8000},
+								{Name: "proxy-ssl", ContainerPort: 8443},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// publishServiceArg builds the --publish-service flag that points the provisioned proxy
+// at its own dedicated Service (the one built by newServiceForGateway), in the same
+// "namespace/name" format parsed by getRefFromPublishService, so the proxy can report its
+// own address back onto that Service rather than the cluster's shared publish Service.
+func publishServiceArg(gateway *gatewayv1alpha2.Gateway) string {
+	return fmt.Sprintf("--publish-service=%s/%s", gateway.Namespace, childName(gateway))
+}
+
+// newServiceForGateway builds the (not-yet-created) Service that exposes the Deployment
+// built by newDeploymentForGateway.
+func newServiceForGateway(gateway *gatewayv1alpha2.Gateway) *corev1.Service {
+	labels := labelsForGateway(gateway)
+
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      childName(gateway),
+			Namespace: gateway.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeLoadBalancer,
+			Selector: labels,
+			Ports: []corev1.ServicePort{
+				{Name: "proxy", Port: 80, TargetPort: intstr.FromInt(8000)},
+				{Name: "proxy-ssl", Port: 443, TargetPort: intstr.FromInt(8443)},
+			},
+		},
+	}
+}
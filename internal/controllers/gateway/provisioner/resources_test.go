@@ -0,0 +1,25 @@
+package provisioner
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+func TestNewDeploymentForGatewayWiresPublishService(t *testing.T) {
+	gw := &gatewayv1alpha2.Gateway{ObjectMeta: metav1.ObjectMeta{Name: "my-gateway", Namespace: "my-ns"}}
+
+	deployment := newDeploymentForGateway(gw, DeploymentConfig{Image: "kong:3.0"})
+
+	containers := deployment.Spec.Template.Spec.Containers
+	if len(containers) != 1 {
+		t.Fatalf("len(containers) = %d, want 1", len(containers))
+	}
+
+	want := "--publish-service=my-ns/gateway-my-gateway"
+	args := containers[0].Args
+	if len(args) != 1 || args[0] != want {
+		t.Errorf("Args = %v, want [%q]", args, want)
+	}
+}